@@ -0,0 +1,28 @@
+// Package config implements `instill config`, for inspecting and editing
+// the CLI's own config.yml/hosts.yml.
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// Factory is the minimal set of dependencies the config subcommands need.
+type Factory struct {
+	Config func() (config.Config, error)
+}
+
+func NewCmdConfig(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage instill configuration",
+	}
+
+	cmd.PersistentFlags().BoolVar(&config.UsePlaintextSecrets, "plaintext", false,
+		"store credentials in hosts.yml instead of the OS keyring (for CI and other headless environments)")
+
+	cmd.AddCommand(NewCmdDump(f))
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func NewCmdDump(f *Factory) *cobra.Command {
+	var resolved bool
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the effective configuration",
+		Long: `Print the effective configuration.
+
+With --resolved, print the merged view of every known setting after
+applying the overlay chain (schema default, config.yml/hosts.yml,
+INSTILL_* environment variable), along with where each value came from.
+Command-line flag overrides are not applied in this view.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !resolved {
+				return cmd.Help()
+			}
+
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			host, _ := cfg.DefaultHost()
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 2, 2, ' ', 0)
+			defer w.Flush()
+
+			fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+			for _, rv := range config.ResolveAll(cfg, host) {
+				fmt.Fprintf(w, "%s\t%s\t%s (%s)\n", rv.Key, rv.Value, rv.Provenance, rv.Source)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&resolved, "resolved", false, "print the merged effective configuration with provenance")
+
+	return cmd
+}
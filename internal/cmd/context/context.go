@@ -0,0 +1,38 @@
+// Package context implements `instill context`, which lets users switch
+// between multiple named host/credential pairs the way `kubectl config
+// use-context` switches between clusters.
+package context
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+// Factory is the minimal set of dependencies the context subcommands need.
+// It mirrors the factory pattern used by the rest of the CLI's command
+// packages so that config loading can be stubbed out in tests.
+type Factory struct {
+	Config func() (config.Config, error)
+}
+
+func NewCmdContext(f *Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage instill hosts/credentials as named contexts",
+		Long: `Define and switch between multiple instill contexts, each binding a host,
+user or token, and default namespace/organization. This is similar to how
+kubectl manages clusters in a kubeconfig file.`,
+	}
+
+	cmd.PersistentFlags().BoolVar(&config.UsePlaintextSecrets, "plaintext", false,
+		"store credentials in hosts.yml instead of the OS keyring (for CI and other headless environments)")
+
+	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdCurrent(f))
+	cmd.AddCommand(NewCmdUse(f))
+	cmd.AddCommand(NewCmdRename(f))
+	cmd.AddCommand(NewCmdDelete(f))
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/instill-ai/cli/internal/config"
+)
+
+func testFactory(c config.Config) *Factory {
+	return &Factory{Config: func() (config.Config, error) { return c, nil }}
+}
+
+func TestCmdContext_UseThenList(t *testing.T) {
+	t.Setenv(config.InstillConfigDir, t.TempDir())
+
+	c := config.NewBlankConfig()
+	if err := c.Contexts().Add(config.Context{Name: "prod", Host: "api.instill.tech"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	f := testFactory(c)
+
+	useCmd := NewCmdUse(f)
+	useCmd.SetArgs([]string{"prod"})
+	useCmd.SetOut(&bytes.Buffer{})
+	if err := useCmd.Execute(); err != nil {
+		t.Fatalf("use failed: %v", err)
+	}
+
+	cur, err := c.Contexts().Current()
+	if err != nil || cur.Name != "prod" {
+		t.Fatalf("expected current context to be prod, got %+v, err=%v", cur, err)
+	}
+
+	var listOut bytes.Buffer
+	listCmd := NewCmdList(f)
+	listCmd.SetOut(&listOut)
+	if err := listCmd.Execute(); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !bytes.Contains(listOut.Bytes(), []byte("prod")) {
+		t.Fatalf("expected list output to mention prod, got: %s", listOut.String())
+	}
+}
+
+func TestNewCmdContext_PlaintextFlag(t *testing.T) {
+	t.Cleanup(func() { config.UsePlaintextSecrets = false })
+
+	cmd := NewCmdContext(testFactory(config.NewBlankConfig()))
+	cmd.SetArgs([]string{"--plaintext", "list"})
+	cmd.SetOut(&bytes.Buffer{})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+
+	if !config.UsePlaintextSecrets {
+		t.Fatal("expected --plaintext to set config.UsePlaintextSecrets")
+	}
+}
@@ -0,0 +1,28 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdCurrent(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the active context",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			ctx, err := cfg.Contexts().Current()
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), ctx.Name)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDelete(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Contexts().Delete(args[0]); err != nil {
+				return err
+			}
+
+			if err := cfg.Write(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted context %q\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdList(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List available contexts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			contexts := cfg.Contexts().List()
+			current, _ := cfg.Contexts().Current()
+
+			for _, ctx := range contexts {
+				marker := " "
+				if ctx.Name == current.Name {
+					marker = "*"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s\t%s\n", marker, ctx.Name, ctx.Host)
+			}
+
+			return nil
+		},
+	}
+}
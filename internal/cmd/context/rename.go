@@ -0,0 +1,32 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRename(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a context",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Contexts().Rename(args[0], args[1]); err != nil {
+				return err
+			}
+
+			if err := cfg.Write(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Renamed context %q to %q\n", args[0], args[1])
+			return nil
+		},
+	}
+}
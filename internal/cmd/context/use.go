@@ -0,0 +1,32 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmdUse(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Switch the active context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+
+			if err := cfg.Contexts().Use(args[0]); err != nil {
+				return err
+			}
+
+			if err := cfg.Write(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", args[0])
+			return nil
+		},
+	}
+}
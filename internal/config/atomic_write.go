@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// atomicWriteFile writes data to filename without ever leaving it
+// truncated or partially written, even if the process is killed mid-write:
+// it writes to a sibling temp file, fsyncs it, renames it over filename, and
+// then fsyncs the containing directory so the rename itself is durable. On
+// POSIX, rename is
+// already an atomic replace; on Windows, os.Rename is implemented on top of
+// MoveFileEx with MOVEFILE_REPLACE_EXISTING, giving the same guarantee.
+//
+// Concurrent writers are serialized with an OS-level advisory lock on
+// filename+".lock" so that two instill processes racing to write the same
+// config can't interleave and corrupt it.
+func atomicWriteFile(filename string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(filename)
+	if err = os.MkdirAll(dir, 0771); err != nil {
+		return pathError(err)
+	}
+
+	lock, err := lockConfigFile(filename)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		if err != nil {
+			os.Remove(tmpName)
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmpName, perm); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+
+	// Best effort: not all platforms support fsyncing a directory (notably
+	// Windows), so a failure here doesn't invalidate the rename above.
+	_ = syncDir(dir)
+
+	return nil
+}
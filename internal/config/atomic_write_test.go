@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAtomicWriteFile_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yml")
+
+	if err := atomicWriteFile(filename, []byte("hello: world\n"), 0600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+	if string(data) != "hello: world\n" {
+		t.Fatalf("got %q, want %q", data, "hello: world\n")
+	}
+}
+
+// TestAtomicWriteFile_FailedRenameLeavesOriginalIntact injects a fault at
+// the rename step (the destination is a directory, which os.Rename can
+// never replace with a regular file, regardless of permissions) and checks
+// that the existing destination is left untouched and no temp file
+// leaks - the crash-in-the-middle guarantee atomicWriteFile exists for.
+func TestAtomicWriteFile_FailedRenameLeavesOriginalIntact(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yml")
+
+	if err := os.Mkdir(filename, 0700); err != nil {
+		t.Fatalf("failed to set up fault: %v", err)
+	}
+
+	err := atomicWriteFile(filename, []byte("hello: world\n"), 0600)
+	if err == nil {
+		t.Fatal("expected an error renaming over a directory, got nil")
+	}
+
+	info, statErr := os.Stat(filename)
+	if statErr != nil {
+		t.Fatalf("destination disappeared after failed write: %v", statErr)
+	}
+	if !info.IsDir() {
+		t.Fatalf("destination was replaced despite the failed rename")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list temp dir: %v", err)
+	}
+	for _, e := range entries {
+		if e.Name() != filepath.Base(filename) && e.Name() != filepath.Base(filename)+".lock" {
+			t.Fatalf("leftover temp file after failed write: %s", e.Name())
+		}
+	}
+}
+
+// TestAtomicWriteFile_FailedWritePreservesPreviousContent simulates a crash
+// between the initial successful write and a subsequent failing one: the
+// first write must remain readable after the second fails.
+func TestAtomicWriteFile_FailedWritePreservesPreviousContent(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.yml")
+
+	if err := atomicWriteFile(filename, []byte("version: 1\n"), 0600); err != nil {
+		t.Fatalf("unexpected error on first write: %v", err)
+	}
+
+	// Replace the lock file with a directory so the *second* write fails
+	// before it can replace the target, mimicking a write that is
+	// interrupted before it can complete.
+	if err := os.Remove(filename + ".lock"); err != nil {
+		t.Fatalf("failed to set up fault: %v", err)
+	}
+	if err := os.Mkdir(filename+".lock", 0700); err != nil {
+		t.Fatalf("failed to set up fault: %v", err)
+	}
+
+	if err := atomicWriteFile(filename, []byte("version: 2\n"), 0600); err == nil {
+		t.Fatal("expected second write to fail")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil || string(data) != "version: 1\n" {
+		t.Fatalf("expected the first write's content to survive, got %q, err=%v", data, err)
+	}
+}
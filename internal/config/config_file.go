@@ -10,15 +10,22 @@ import (
 	"syscall"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/cli/internal/config/configschema"
 )
 
 const (
 	InstillConfigDir = "INSTILL_CONFIG_DIR"
-	xdgConfigHome    = "XDG_CONFIG_HOME"
-	xdgStateHome     = "XDG_STATE_HOME"
-	xdgDataHome      = "XDG_DATA_HOME"
-	appData          = "AppData"
-	localAppData     = "LocalAppData"
+	// InstillConfigs is a colon-separated (os.PathListSeparator) list of
+	// config.yml paths to deep-merge, modeled after KUBECONFIG. The first
+	// entry takes precedence on conflicts and is the only one mutations are
+	// written back to.
+	InstillConfigs = "INSTILL_CONFIGS"
+	xdgConfigHome  = "XDG_CONFIG_HOME"
+	xdgStateHome   = "XDG_STATE_HOME"
+	xdgDataHome    = "XDG_DATA_HOME"
+	appData        = "AppData"
+	localAppData   = "LocalAppData"
 )
 
 // ConfigDir returns config dirpath with precedence:
@@ -162,7 +169,68 @@ func HostsConfigFile() string {
 }
 
 func ParseDefaultConfig() (Config, error) {
-	return parseConfig(ConfigFile())
+	files := mergedConfigFiles()
+	if len(files) == 1 {
+		// No merge to do, but still honor files[0] as the write target
+		// instead of always defaulting to ConfigFile() - a single-entry
+		// INSTILL_CONFIGS should behave like any other entry in the chain.
+		return parseConfig(files[0])
+	}
+
+	root := NewBlankRoot()
+	// mergeConfigRoots(dst, src) keeps dst's value on any key collision, so
+	// walk front to back: files[0] is merged in first (as the base it never
+	// loses to anything merged afterward) and therefore wins, matching
+	// INSTILL_CONFIGS's precedence order.
+	for _, file := range files {
+		fileRoot, err := loadAndMigrateFileRoot(file)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfigRoots(root.Content[0], fileRoot.Content[0])
+	}
+
+	cfg, err := finishParsingConfig(files[0], root)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// mergedConfigFiles returns the list of config.yml paths to merge, per
+// INSTILL_CONFIGS. When unset, it returns just the default ConfigFile.
+func mergedConfigFiles() []string {
+	raw := os.Getenv(InstillConfigs)
+	if raw == "" {
+		return []string{ConfigFile()}
+	}
+
+	var files []string
+	for _, p := range filepath.SplitList(raw) {
+		if p != "" {
+			files = append(files, p)
+		}
+	}
+	if len(files) == 0 {
+		return []string{ConfigFile()}
+	}
+	return files
+}
+
+// mergeConfigRoots deep-merges src into dst in place. Scalar and sequence
+// keys already present in dst are left untouched; mapping keys are merged
+// recursively so that, e.g., hosts defined in different files are additive.
+func mergeConfigRoots(dst, src *yaml.Node) {
+	for i := 0; i < len(src.Content)-1; i += 2 {
+		key, srcVal := src.Content[i], src.Content[i+1]
+		if dstVal, ok := findEntry(dst, key.Value); ok {
+			if dstVal.Kind == yaml.MappingNode && srcVal.Kind == yaml.MappingNode {
+				mergeConfigRoots(dstVal, srcVal)
+			}
+			continue
+		}
+		dst.Content = append(dst.Content, key, srcVal)
+	}
 }
 
 var ReadConfigFile = func(filename string) ([]byte, error) {
@@ -180,33 +248,20 @@ var ReadConfigFile = func(filename string) ([]byte, error) {
 	return data, nil
 }
 
-var WriteConfigFile = func(filename string, data []byte) (err error) {
-	err = os.MkdirAll(filepath.Dir(filename), 0771)
-	if err != nil {
-		err = pathError(err)
-		return
-	}
-
-	var cfgFile *os.File
-	cfgFile, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600) // cargo coded from setup
-	if err != nil {
-		return
-	}
-	defer func() {
-		if cleanupErr := cfgFile.Close(); cleanupErr != nil {
-			err = errors.Join(err, cleanupErr)
-		}
-	}()
-
-	_, err = cfgFile.Write(data)
-
-	return
+var WriteConfigFile = func(filename string, data []byte) error {
+	return atomicWriteFile(filename, data, 0600)
 }
 
 var BackupConfigFile = func(filename string) error {
 	return os.Rename(filename, filename+".bak")
 }
 
+// RestoreConfigFile reverses a BackupConfigFile, moving filename+".bak"
+// back over filename.
+var RestoreConfigFile = func(filename string) error {
+	return os.Rename(filename+".bak", filename)
+}
+
 func parseConfigFile(filename string) ([]byte, *yaml.Node, error) {
 	data, err := ReadConfigFile(filename)
 	if err != nil {
@@ -261,7 +316,7 @@ func migrateConfig(filename string) error {
 		return fmt.Errorf("error decoding legacy format: %w", err)
 	}
 
-	cfg := NewBlankConfig()
+	cfg := NewConfigForFile(NewBlankRoot(), filename)
 	for hostname, entries := range hosts {
 		if len(entries) < 1 {
 			continue
@@ -279,46 +334,103 @@ func migrateConfig(filename string) error {
 		return fmt.Errorf("failed to back up existing config: %w", err)
 	}
 
-	return cfg.Write()
+	if err := cfg.Write(); err != nil {
+		// BackupConfigFile renamed the legacy file out of the way, so a
+		// failed write here would otherwise leave the user with neither a
+		// config.yml nor a usable .bak. Put the original back.
+		if restoreErr := RestoreConfigFile(filename); restoreErr != nil {
+			return fmt.Errorf("failed to write migrated config (%w) and failed to restore backup: %v", err, restoreErr)
+		}
+		return fmt.Errorf("failed to write migrated config, restored previous config.yml: %w", err)
+	}
+
+	return nil
 }
 
 func parseConfig(filename string) (Config, error) {
+	root, err := loadAndMigrateFileRoot(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return finishParsingConfig(filename, root)
+}
+
+// loadAndMigrateFileRoot reads filename and, if it's still in the legacy
+// single-host shape, upgrades it in place (via migrateConfig) and re-reads
+// the result. A missing file yields a blank root rather than an error.
+func loadAndMigrateFileRoot(filename string) (*yaml.Node, error) {
 	_, root, err := parseConfigFile(filename)
 	if err != nil {
 		if os.IsNotExist(err) {
-			root = NewBlankRoot()
-		} else {
-			return nil, err
+			return NewBlankRoot(), nil
 		}
+		return nil, err
 	}
 
-	// merge hosts.yml under the "hosts" key
-	if isLegacy(root) {
-		err = migrateConfig(filename)
-		if err != nil {
-			return nil, fmt.Errorf("error migrating legacy config: %w", err)
+	if !isLegacy(root) {
+		return root, nil
+	}
+
+	if err := migrateConfig(filename); err != nil {
+		return nil, fmt.Errorf("error migrating legacy config: %w", err)
+	}
+
+	_, root, err = parseConfigFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reparse migrated config: %w", err)
+	}
+	return root, nil
+}
+
+// finishParsingConfig merges hosts.yml into root under the "hosts" key,
+// upgrades a contextless single-host config into a default context, and
+// wraps the result as a Config.
+func finishParsingConfig(filename string, root *yaml.Node) (Config, error) {
+	if errs := configschema.Validate(root); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if _, hostsRoot, err := parseConfigFile(HostsConfigFile()); err == nil {
+		if len(hostsRoot.Content[0].Content) > 0 {
+			newContent := []*yaml.Node{
+				{Value: "hosts"},
+				hostsRoot.Content[0],
+			}
+			restContent := root.Content[0].Content
+			root.Content[0].Content = append(newContent, restContent...)
 		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
 
-		_, root, err = parseConfigFile(filename)
-		if err != nil {
-			return nil, fmt.Errorf("failed to reparse migrated config: %w", err)
+	c := NewConfigForFile(root, filename)
+
+	// Persist the synthesized default context immediately: otherwise every
+	// read-only command recomputes it in memory without ever saving it, and
+	// `instill context use default` fails on a fresh install where nothing
+	// has triggered a write yet.
+	contextChanged, err := migrateToDefaultContext(c)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating to default context: %w", err)
+	}
+	if contextChanged {
+		if err := c.Write(); err != nil {
+			return nil, fmt.Errorf("failed to persist default context: %w", err)
 		}
-	} else {
-		if _, hostsRoot, err := parseConfigFile(HostsConfigFile()); err == nil {
-			if len(hostsRoot.Content[0].Content) > 0 {
-				newContent := []*yaml.Node{
-					{Value: "hosts"},
-					hostsRoot.Content[0],
-				}
-				restContent := root.Content[0].Content
-				root.Content[0].Content = append(newContent, restContent...)
-			}
-		} else if !errors.Is(err, os.ErrNotExist) {
-			return nil, err
+	}
+
+	secretsChanged, err := migrateLegacySecrets(c)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating plaintext tokens to secret store: %w", err)
+	}
+	if secretsChanged {
+		if err := c.Write(); err != nil {
+			return nil, fmt.Errorf("failed to persist secret store migration: %w", err)
 		}
 	}
 
-	return NewConfig(root), nil
+	return c, nil
 }
 
 func pathError(err error) error {
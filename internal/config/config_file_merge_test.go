@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDefaultConfig_MergePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(InstillConfigDir, dir)
+
+	primary := filepath.Join(dir, "primary.yml")
+	secondary := filepath.Join(dir, "secondary.yml")
+
+	if err := os.WriteFile(primary, []byte("editor: vim\n"), 0600); err != nil {
+		t.Fatalf("failed to write primary config: %v", err)
+	}
+	if err := os.WriteFile(secondary, []byte("editor: nano\npager: less\n"), 0600); err != nil {
+		t.Fatalf("failed to write secondary config: %v", err)
+	}
+
+	t.Setenv(InstillConfigs, primary+string(os.PathListSeparator)+secondary)
+
+	c, err := ParseDefaultConfig()
+	if err != nil {
+		t.Fatalf("ParseDefaultConfig failed: %v", err)
+	}
+
+	if v, err := c.Get("", "editor"); err != nil || v != "vim" {
+		t.Fatalf("editor = %q, err=%v; want %q (first file wins on collision)", v, err, "vim")
+	}
+	if v, err := c.Get("", "pager"); err != nil || v != "less" {
+		t.Fatalf("pager = %q, err=%v; want %q (merged in from the second file)", v, err, "less")
+	}
+}
+
+func TestParseDefaultConfig_WriteTargetsFirstFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(InstillConfigDir, dir)
+
+	primary := filepath.Join(dir, "primary.yml")
+	secondary := filepath.Join(dir, "secondary.yml")
+
+	if err := os.WriteFile(primary, []byte("editor: vim\n"), 0600); err != nil {
+		t.Fatalf("failed to write primary config: %v", err)
+	}
+	if err := os.WriteFile(secondary, []byte("pager: less\n"), 0600); err != nil {
+		t.Fatalf("failed to write secondary config: %v", err)
+	}
+
+	t.Setenv(InstillConfigs, primary+string(os.PathListSeparator)+secondary)
+
+	c, err := ParseDefaultConfig()
+	if err != nil {
+		t.Fatalf("ParseDefaultConfig failed: %v", err)
+	}
+
+	if err := c.Set("", "editor", "emacs"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := c.Write(); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	primaryData, err := os.ReadFile(primary)
+	if err != nil {
+		t.Fatalf("failed to read primary config: %v", err)
+	}
+	if !strings.Contains(string(primaryData), "emacs") {
+		t.Fatalf("expected the write to land in %s, got:\n%s", primary, primaryData)
+	}
+
+	secondaryData, err := os.ReadFile(secondary)
+	if err != nil {
+		t.Fatalf("failed to read secondary config: %v", err)
+	}
+	if strings.Contains(string(secondaryData), "emacs") {
+		t.Fatalf("write leaked into the non-target file %s", secondary)
+	}
+
+	if _, err := os.Stat(ConfigFile()); !os.IsNotExist(err) {
+		t.Fatalf("expected no config.yml at the OS-default location, got err=%v", err)
+	}
+}
+
+func TestParseDefaultConfig_MergePrecedence_NestedHostKeys(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(InstillConfigDir, dir)
+
+	primary := filepath.Join(dir, "primary.yml")
+	secondary := filepath.Join(dir, "secondary.yml")
+
+	if err := os.WriteFile(primary, []byte("hosts:\n  api.instill.tech:\n    user: primary-user\n"), 0600); err != nil {
+		t.Fatalf("failed to write primary config: %v", err)
+	}
+	if err := os.WriteFile(secondary, []byte("hosts:\n  api.instill.tech:\n    user: secondary-user\n    organization: acme\n"), 0600); err != nil {
+		t.Fatalf("failed to write secondary config: %v", err)
+	}
+
+	t.Setenv(InstillConfigs, primary+string(os.PathListSeparator)+secondary)
+
+	c, err := ParseDefaultConfig()
+	if err != nil {
+		t.Fatalf("ParseDefaultConfig failed: %v", err)
+	}
+
+	if v, err := c.Get("api.instill.tech", "user"); err != nil || v != "primary-user" {
+		t.Fatalf("user = %q, err=%v; want %q (first file wins on the nested host key too)", v, err, "primary-user")
+	}
+	if v, err := c.Get("api.instill.tech", "organization"); err != nil || v != "acme" {
+		t.Fatalf("organization = %q, err=%v; want %q (merged in from the second file)", v, err, "acme")
+	}
+}
+
+func TestParseDefaultConfig_MigratesLegacyFileInChain(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(InstillConfigDir, dir)
+
+	legacy := filepath.Join(dir, "legacy.yml")
+	legacyContent := "instill.tech:\n  - user: monalisa\n    oauth_token: abc123\n"
+	if err := os.WriteFile(legacy, []byte(legacyContent), 0600); err != nil {
+		t.Fatalf("failed to write legacy config: %v", err)
+	}
+
+	other := filepath.Join(dir, "other.yml")
+	if err := os.WriteFile(other, []byte("pager: less\n"), 0600); err != nil {
+		t.Fatalf("failed to write other config: %v", err)
+	}
+
+	t.Setenv(InstillConfigs, legacy+string(os.PathListSeparator)+other)
+
+	c, err := ParseDefaultConfig()
+	if err != nil {
+		t.Fatalf("ParseDefaultConfig failed on a legacy file in the chain: %v", err)
+	}
+
+	hosts := c.Hosts()
+	if len(hosts) != 1 || hosts[0] != "instill.tech" {
+		t.Fatalf("expected the legacy file to be migrated into a host entry, got hosts=%v", hosts)
+	}
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/cli/internal/config/configschema"
+)
+
+// Config is the in-memory representation of the merged config.yml/hosts.yml
+// tree. It is deliberately narrow: callers go through Get/Set rather than
+// poking at the underlying yaml.Node directly.
+//
+// Get and GetOrDefault are provenance-aware in that they apply the same
+// overlay chain ResolveAll reports on: a recognized key's INSTILL_* env
+// var, when set, always wins over whatever is stored in config.yml/
+// hosts.yml, and GetOrDefault falls back to the schema default - see
+// configschema.Fields - rather than hosts.yml alone. ResolveAll is the
+// version of this chain that also reports which layer won, for `instill
+// config dump --resolved`.
+type Config interface {
+	Get(hostname, key string) (string, error)
+	GetOrDefault(hostname, key string) (string, error)
+	Set(hostname, key, value string) error
+	Hosts() []string
+	DefaultHost() (string, error)
+	Write() error
+
+	// Contexts exposes the kubeconfig-style context store layered on top of
+	// the host entries. See context.go.
+	Contexts() *ContextConfig
+
+	Root() *yaml.Node
+}
+
+func NewBlankRoot() *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.DocumentNode,
+		Content: []*yaml.Node{
+			{
+				Kind:    yaml.MappingNode,
+				Content: []*yaml.Node{},
+			},
+		},
+	}
+}
+
+func NewBlankConfig() Config {
+	return NewConfig(NewBlankRoot())
+}
+
+func NewConfig(root *yaml.Node) Config {
+	return &cfg{root: root, writeTarget: ConfigFile()}
+}
+
+// NewConfigForFile is like NewConfig, but Write persists to filename
+// instead of the default ConfigFile() path. finishParsingConfig uses this
+// so that, with INSTILL_CONFIGS set, mutations land on files[0] - the
+// designated write target - rather than silently creating a config.yml at
+// the OS-default location.
+func NewConfigForFile(root *yaml.Node, filename string) Config {
+	return &cfg{root: root, writeTarget: filename}
+}
+
+type cfg struct {
+	root        *yaml.Node
+	writeTarget string
+}
+
+func (c *cfg) Root() *yaml.Node {
+	return c.root
+}
+
+// topLevel returns the root mapping node, creating one if the document is
+// empty.
+func (c *cfg) topLevel() *yaml.Node {
+	if len(c.root.Content) == 0 {
+		c.root.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	return c.root.Content[0]
+}
+
+// findEntry returns the value node mapped to key in m, and whether it was
+// found.
+func findEntry(m *yaml.Node, key string) (*yaml.Node, bool) {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// setEntry upserts key/value into mapping node m.
+func setEntry(m *yaml.Node, key, value string) {
+	for i := 0; i < len(m.Content)-1; i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1].Value = value
+			return
+		}
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+	)
+}
+
+func (c *cfg) hostsNode() *yaml.Node {
+	hosts, ok := findEntry(c.topLevel(), "hosts")
+	if !ok {
+		hosts = &yaml.Node{Kind: yaml.MappingNode}
+		c.topLevel().Content = append(c.topLevel().Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "hosts"}, hosts)
+	}
+	return hosts
+}
+
+func (c *cfg) hostNode(hostname string, create bool) (*yaml.Node, bool) {
+	hosts := c.hostsNode()
+	if entry, ok := findEntry(hosts, hostname); ok {
+		return entry, true
+	}
+	if !create {
+		return nil, false
+	}
+	entry := &yaml.Node{Kind: yaml.MappingNode}
+	hosts.Content = append(hosts.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: hostname}, entry)
+	return entry, true
+}
+
+func (c *cfg) Get(hostname, key string) (string, error) {
+	if field, ok := configschema.Lookup(key); ok && field.EnvVar != "" {
+		if v, ok := os.LookupEnv(field.EnvVar); ok {
+			return v, nil
+		}
+	}
+
+	return c.getStored(hostname, key)
+}
+
+// getStored reads key straight out of config.yml/hosts.yml, resolving a
+// "keyring:..." reference if it finds one, without applying the env
+// overlay - it's the "config.yml" layer of Get's overlay chain.
+func (c *cfg) getStored(hostname, key string) (string, error) {
+	if hostname != "" {
+		if host, ok := c.hostNode(hostname, false); ok {
+			if v, ok := findEntry(host, key); ok {
+				if isSecretRef(v.Value) {
+					return resolveSecretRef(v.Value)
+				}
+				return v.Value, nil
+			}
+		}
+		return "", fmt.Errorf("key %q not found for host %q", key, hostname)
+	}
+
+	if v, ok := findEntry(c.topLevel(), key); ok {
+		return v.Value, nil
+	}
+	return "", fmt.Errorf("key %q not found", key)
+}
+
+// resolveSecretRef dereferences a "keyring:instill/<host>/<key>" pointer
+// stored in hosts.yml against the configured SecretStore.
+func resolveSecretRef(ref string) (string, error) {
+	key, ok := secretStoreKey(ref)
+	if !ok {
+		return "", fmt.Errorf("malformed secret reference %q", ref)
+	}
+	return defaultSecretStore().Get(secretService, key)
+}
+
+func (c *cfg) GetOrDefault(hostname, key string) (string, error) {
+	v, err := c.Get(hostname, key)
+	if err == nil {
+		return v, nil
+	}
+	if field, ok := configschema.Lookup(key); ok && field.Default != "" {
+		return field.Default, nil
+	}
+	return "", err
+}
+
+func (c *cfg) Set(hostname, key, value string) error {
+	if hostname == "" {
+		setEntry(c.topLevel(), key, value)
+		return nil
+	}
+
+	if isSecretKey(key) && !UsePlaintextSecrets {
+		if err := defaultSecretStore().Set(secretService, fmt.Sprintf("%s/%s", hostname, key), value); err != nil {
+			return fmt.Errorf("failed to store %q in secret store: %w", key, err)
+		}
+		value = secretRefFor(hostname, key)
+	}
+
+	host, _ := c.hostNode(hostname, true)
+	setEntry(host, key, value)
+	return nil
+}
+
+func (c *cfg) Hosts() []string {
+	hosts := c.hostsNode()
+	var names []string
+	for i := 0; i < len(hosts.Content)-1; i += 2 {
+		names = append(names, hosts.Content[i].Value)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *cfg) DefaultHost() (string, error) {
+	if ctx, err := c.Contexts().Current(); err == nil {
+		return ctx.Host, nil
+	}
+
+	hosts := c.Hosts()
+	if len(hosts) == 0 {
+		return "", fmt.Errorf("no hosts configured")
+	}
+	return hosts[0], nil
+}
+
+func (c *cfg) Contexts() *ContextConfig {
+	return &ContextConfig{cfg: c}
+}
+
+// migrateLegacySecrets finds hosts.yml entries still holding a plaintext
+// oauth_token and moves them into the configured SecretStore, replacing the
+// value in place with a "keyring:..." reference. It is a no-op under
+// --plaintext. The returned bool reports whether it rewrote anything, so
+// the caller knows to persist the result - otherwise this would redo the
+// keyring/secrets.enc write, and never actually remove the plaintext token
+// from hosts.yml, on every single invocation.
+func migrateLegacySecrets(c Config) (bool, error) {
+	if UsePlaintextSecrets {
+		return false, nil
+	}
+
+	impl, ok := c.(*cfg)
+	if !ok {
+		return false, nil
+	}
+
+	changed := false
+	hosts := impl.hostsNode()
+	for i := 0; i < len(hosts.Content)-1; i += 2 {
+		hostname := hosts.Content[i].Value
+		hostNode := hosts.Content[i+1]
+
+		v, ok := findEntry(hostNode, "oauth_token")
+		if !ok || v.Value == "" || isSecretRef(v.Value) {
+			continue
+		}
+
+		if err := defaultSecretStore().Set(secretService, fmt.Sprintf("%s/oauth_token", hostname), v.Value); err != nil {
+			return changed, fmt.Errorf("failed to migrate token for %q: %w", hostname, err)
+		}
+		v.Value = secretRefFor(hostname, "oauth_token")
+		changed = true
+	}
+
+	return changed, nil
+}
+
+func (c *cfg) Write() error {
+	data, err := yaml.Marshal(c.root)
+	if err != nil {
+		return err
+	}
+	return WriteConfigFile(c.writeTarget, data)
+}
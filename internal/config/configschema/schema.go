@@ -0,0 +1,72 @@
+// Package configschema describes the known instill config.yml surface: the
+// set of recognized keys, their types, defaults, and the environment
+// variable that can override each one. It is a deliberately lightweight
+// stand-in for a full JSON Schema/CUE document - just enough to validate
+// config.yml and drive the env/default overlay in config.Resolve, without
+// pulling in a schema compiler dependency.
+package configschema
+
+// Field describes a single recognized config.yml key.
+type Field struct {
+	Key         string
+	Type        string // "string" or "bool"
+	Default     string
+	Description string
+	// EnvVar is the INSTILL_* environment variable that overrides this key,
+	// if it has one.
+	EnvVar string
+	// Enum restricts a "string" field to a fixed set of values. Empty means
+	// any string is accepted.
+	Enum []string
+}
+
+// Fields is the full set of recognized top-level config.yml keys.
+var Fields = []Field{
+	{
+		Key:         "api_url",
+		Type:        "string",
+		Default:     "https://api.instill.tech",
+		Description: "Base URL for the instill API.",
+		EnvVar:      "INSTILL_HOST_API_URL",
+	},
+	{
+		Key:         "git_protocol",
+		Type:        "string",
+		Default:     "https",
+		Description: "Protocol used when cloning instill-hosted repositories.",
+		EnvVar:      "INSTILL_GIT_PROTOCOL",
+		Enum:        []string{"https", "ssh"},
+	},
+	{
+		Key:         "editor",
+		Type:        "string",
+		Default:     "",
+		Description: "Editor command instill spawns for interactive edits.",
+		EnvVar:      "INSTILL_EDITOR",
+	},
+	{
+		Key:         "prompt",
+		Type:        "string",
+		Default:     "enabled",
+		Description: "Whether interactive prompts are allowed (\"enabled\" or \"disabled\").",
+		EnvVar:      "INSTILL_PROMPT_DISABLED",
+		Enum:        []string{"enabled", "disabled"},
+	},
+	{
+		Key:         "pager",
+		Type:        "string",
+		Default:     "",
+		Description: "Pager command used for long output.",
+		EnvVar:      "INSTILL_PAGER",
+	},
+}
+
+// Lookup returns the Field for key, if it's part of the known schema.
+func Lookup(key string) (Field, bool) {
+	for _, f := range Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
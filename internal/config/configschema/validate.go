@@ -0,0 +1,79 @@
+package configschema
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError points at the offending value's line/column in the source
+// document, taken straight from the yaml.Node, so errors can be surfaced
+// the way a linter would.
+type ValidationError struct {
+	Key    string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("config.yml:%d:%d: %s: %s", e.Line, e.Column, e.Key, e.Msg)
+}
+
+// Validate checks every recognized key in root's top-level mapping against
+// its declared type. Unrecognized keys are ignored: the schema documents
+// known settings, it doesn't forbid forward-compatible ones.
+func Validate(root *yaml.Node) []error {
+	var errs []error
+	if root == nil || len(root.Content) == 0 {
+		return errs
+	}
+
+	top := root.Content[0]
+	if top.Kind != yaml.MappingNode {
+		return errs
+	}
+
+	for i := 0; i < len(top.Content)-1; i += 2 {
+		keyNode, valNode := top.Content[i], top.Content[i+1]
+		field, ok := Lookup(keyNode.Value)
+		if !ok || valNode.Kind != yaml.ScalarNode {
+			continue
+		}
+		if err := checkType(field, valNode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func checkType(field Field, val *yaml.Node) error {
+	switch field.Type {
+	case "bool":
+		if val.Value != "true" && val.Value != "false" {
+			return &ValidationError{Key: field.Key, Line: val.Line, Column: val.Column, Msg: "expected a boolean"}
+		}
+	}
+
+	if len(field.Enum) > 0 && !isOneOf(val.Value, field.Enum) {
+		return &ValidationError{
+			Key:    field.Key,
+			Line:   val.Line,
+			Column: val.Column,
+			Msg:    fmt.Sprintf("must be one of %s", strings.Join(field.Enum, ", ")),
+		}
+	}
+
+	return nil
+}
+
+func isOneOf(value string, allowed []string) bool {
+	for _, v := range allowed {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
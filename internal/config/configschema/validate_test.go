@@ -0,0 +1,52 @@
+package configschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func parseRoot(t *testing.T, doc string) *yaml.Node {
+	t.Helper()
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(doc), &root); err != nil {
+		t.Fatalf("failed to parse test document: %v", err)
+	}
+	return &root
+}
+
+func TestValidate_RejectsUnknownEnumValue(t *testing.T) {
+	root := parseRoot(t, "git_protocol: ftp\n")
+
+	errs := Validate(root)
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error, got %v", errs)
+	}
+
+	verr, ok := errs[0].(*ValidationError)
+	if !ok || verr.Key != "git_protocol" {
+		t.Fatalf("expected a ValidationError for git_protocol, got %v", errs[0])
+	}
+}
+
+func TestValidate_RejectsUnknownEnumValue_Prompt(t *testing.T) {
+	root := parseRoot(t, "prompt: sometimes\n")
+
+	errs := Validate(root)
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error, got %v", errs)
+	}
+
+	verr, ok := errs[0].(*ValidationError)
+	if !ok || verr.Key != "prompt" {
+		t.Fatalf("expected a ValidationError for prompt, got %v", errs[0])
+	}
+}
+
+func TestValidate_AcceptsKnownEnumValue(t *testing.T) {
+	root := parseRoot(t, "git_protocol: ssh\nprompt: disabled\n")
+
+	if errs := Validate(root); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Context binds a name to a host/credential pair plus the defaults that
+// should apply when that context is active, mirroring the "context" concept
+// from kubeconfig. It is the unit `instill context use` switches between.
+type Context struct {
+	Name         string
+	Host         string
+	User         string
+	Namespace    string
+	Organization string
+}
+
+// currentContextKey is the top-level config.yml key that records which
+// context is active, analogous to kubeconfig's current-context.
+const currentContextKey = "current-context"
+
+// ContextConfig is the context store layered on top of the host entries in
+// a Config. It is obtained via Config.Contexts().
+type ContextConfig struct {
+	cfg *cfg
+}
+
+func (c *ContextConfig) contextsNode() *yaml.Node {
+	contexts, ok := findEntry(c.cfg.topLevel(), "contexts")
+	if !ok {
+		contexts = &yaml.Node{Kind: yaml.MappingNode}
+		c.cfg.topLevel().Content = append(c.cfg.topLevel().Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "contexts"}, contexts)
+	}
+	return contexts
+}
+
+func (c *ContextConfig) entryNode(name string, create bool) (*yaml.Node, bool) {
+	contexts := c.contextsNode()
+	if entry, ok := findEntry(contexts, name); ok {
+		return entry, true
+	}
+	if !create {
+		return nil, false
+	}
+	entry := &yaml.Node{Kind: yaml.MappingNode}
+	contexts.Content = append(contexts.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: name}, entry)
+	return entry, true
+}
+
+func contextFromNode(name string, entry *yaml.Node) Context {
+	ctx := Context{Name: name}
+	for i := 0; i < len(entry.Content)-1; i += 2 {
+		switch entry.Content[i].Value {
+		case "host":
+			ctx.Host = entry.Content[i+1].Value
+		case "user":
+			ctx.User = entry.Content[i+1].Value
+		case "namespace":
+			ctx.Namespace = entry.Content[i+1].Value
+		case "organization":
+			ctx.Organization = entry.Content[i+1].Value
+		}
+	}
+	return ctx
+}
+
+// List returns every defined context, in declaration order.
+func (c *ContextConfig) List() []Context {
+	contexts := c.contextsNode()
+	var out []Context
+	for i := 0; i < len(contexts.Content)-1; i += 2 {
+		out = append(out, contextFromNode(contexts.Content[i].Value, contexts.Content[i+1]))
+	}
+	return out
+}
+
+// Current returns the active context, as recorded by current-context.
+func (c *ContextConfig) Current() (Context, error) {
+	name, err := c.cfg.Get("", currentContextKey)
+	if err != nil || name == "" {
+		return Context{}, fmt.Errorf("no context is currently set; run `instill context use <name>`")
+	}
+	entry, ok := c.entryNode(name, false)
+	if !ok {
+		return Context{}, fmt.Errorf("current context %q no longer exists", name)
+	}
+	return contextFromNode(name, entry), nil
+}
+
+// Use switches current-context to name, failing if it is not defined.
+func (c *ContextConfig) Use(name string) error {
+	if _, ok := c.entryNode(name, false); !ok {
+		return fmt.Errorf("no such context %q", name)
+	}
+	return c.cfg.Set("", currentContextKey, name)
+}
+
+// Add defines or overwrites a context.
+func (c *ContextConfig) Add(ctx Context) error {
+	if ctx.Name == "" {
+		return fmt.Errorf("context name cannot be empty")
+	}
+	entry, _ := c.entryNode(ctx.Name, true)
+	entry.Content = nil
+	setEntry(entry, "host", ctx.Host)
+	setEntry(entry, "user", ctx.User)
+	if ctx.Namespace != "" {
+		setEntry(entry, "namespace", ctx.Namespace)
+	}
+	if ctx.Organization != "" {
+		setEntry(entry, "organization", ctx.Organization)
+	}
+	return nil
+}
+
+// Rename renames a context in place, updating current-context if it pointed
+// at the old name.
+func (c *ContextConfig) Rename(oldName, newName string) error {
+	contexts := c.contextsNode()
+	for i := 0; i < len(contexts.Content)-1; i += 2 {
+		if contexts.Content[i].Value == oldName {
+			contexts.Content[i].Value = newName
+			if cur, err := c.cfg.Get("", currentContextKey); err == nil && cur == oldName {
+				return c.cfg.Set("", currentContextKey, newName)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no such context %q", oldName)
+}
+
+// Delete removes a context. Deleting the active context clears
+// current-context.
+func (c *ContextConfig) Delete(name string) error {
+	contexts := c.contextsNode()
+	for i := 0; i < len(contexts.Content)-1; i += 2 {
+		if contexts.Content[i].Value == name {
+			contexts.Content = append(contexts.Content[:i], contexts.Content[i+2:]...)
+			if cur, err := c.cfg.Get("", currentContextKey); err == nil && cur == name {
+				setEntry(c.cfg.topLevel(), currentContextKey, "")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no such context %q", name)
+}
+
+// migrateToDefaultContext upgrades a single-host config that predates
+// contexts by synthesizing a "default" context from its first host and
+// pointing current-context at it. It is a no-op once any context exists.
+// The returned bool reports whether it created anything, so callers know
+// whether the in-memory tree needs to be persisted.
+func migrateToDefaultContext(c Config) (bool, error) {
+	cc := c.Contexts()
+	if len(cc.List()) > 0 {
+		return false, nil
+	}
+
+	hosts := c.Hosts()
+	if len(hosts) == 0 {
+		return false, nil
+	}
+
+	host := hosts[0]
+	user, _ := c.Get(host, "user")
+	if err := cc.Add(Context{Name: "default", Host: host, User: user}); err != nil {
+		return false, err
+	}
+	if err := cc.Use("default"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import "testing"
+
+func TestContextConfig_CRUD(t *testing.T) {
+	c := NewBlankConfig()
+	cc := c.Contexts()
+
+	if got := cc.List(); len(got) != 0 {
+		t.Fatalf("expected no contexts initially, got %v", got)
+	}
+
+	if err := cc.Add(Context{Name: "prod", Host: "api.instill.tech", User: "monalisa"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := cc.Use("prod"); err != nil {
+		t.Fatalf("Use failed: %v", err)
+	}
+
+	cur, err := cc.Current()
+	if err != nil || cur.Host != "api.instill.tech" || cur.User != "monalisa" {
+		t.Fatalf("Current() = %+v, err=%v; want the prod context", cur, err)
+	}
+
+	if err := cc.Rename("prod", "production"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	cur, err = cc.Current()
+	if err != nil || cur.Name != "production" {
+		t.Fatalf("expected current-context to follow the rename, got %+v, err=%v", cur, err)
+	}
+
+	if err := cc.Delete("production"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := cc.Current(); err == nil {
+		t.Fatal("expected no current context after deleting it")
+	}
+	if got := cc.List(); len(got) != 0 {
+		t.Fatalf("expected no contexts after delete, got %v", got)
+	}
+}
+
+func TestMigrateToDefaultContext(t *testing.T) {
+	c := NewBlankConfig()
+	if err := c.Set("api.instill.tech", "user", "monalisa"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	changed, err := migrateToDefaultContext(c)
+	if err != nil {
+		t.Fatalf("migrateToDefaultContext failed: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected migrateToDefaultContext to report a change")
+	}
+
+	cur, err := c.Contexts().Current()
+	if err != nil || cur.Name != "default" || cur.Host != "api.instill.tech" || cur.User != "monalisa" {
+		t.Fatalf("got %+v, err=%v; want a default context for api.instill.tech/monalisa", cur, err)
+	}
+
+	changed, err = migrateToDefaultContext(c)
+	if err != nil {
+		t.Fatalf("second migrateToDefaultContext call failed: %v", err)
+	}
+	if changed {
+		t.Fatal("migrateToDefaultContext should be a no-op once a context exists")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package config
+
+import "os"
+
+// syncDir fsyncs a directory so that a preceding rename into it is durable
+// across a crash, not just visible to other processes.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
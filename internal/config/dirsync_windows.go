@@ -0,0 +1,10 @@
+//go:build windows
+
+package config
+
+// syncDir is a no-op on Windows: directory handles can't be fsynced, and
+// NTFS rename durability is already covered by MoveFileEx's own metadata
+// flush.
+func syncDir(dir string) error {
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileLock is an OS-level advisory lock held on a sibling ".lock" file,
+// used to keep concurrent `instill` invocations from corrupting config.yml
+// or hosts.yml.
+type fileLock struct {
+	f *os.File
+}
+
+func lockConfigFile(target string) (*fileLock, error) {
+	f, err := os.OpenFile(target+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &fileLock{f: f}, nil
+}
+
+func (l *fileLock) Unlock() error {
+	defer l.f.Close()
+	return syscall.Flock(int(l.f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLockConfigFile_Exclusive verifies that a second lockConfigFile call
+// against the same target blocks until the first is released - the
+// property WriteConfigFile relies on to keep two concurrent `instill`
+// invocations from interleaving writes to the same config.yml.
+func TestLockConfigFile_Exclusive(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "config.yml")
+
+	first, err := lockConfigFile(target)
+	if err != nil {
+		t.Fatalf("failed to acquire first lock: %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		second, err := lockConfigFile(target)
+		if err != nil {
+			t.Errorf("failed to acquire second lock: %v", err)
+			return
+		}
+		defer second.Unlock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second lock acquired while the first was still held")
+	case <-time.After(100 * time.Millisecond):
+		// expected: the second lock is blocked
+	}
+
+	if err := first.Unlock(); err != nil {
+		t.Fatalf("failed to release first lock: %v", err)
+	}
+
+	select {
+	case <-acquired:
+		// expected: releasing the first lock unblocks the second
+	case <-time.After(time.Second):
+		t.Fatal("second lock was never acquired after the first was released")
+	}
+}
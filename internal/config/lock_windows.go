@@ -0,0 +1,37 @@
+//go:build windows
+
+package config
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// fileLock is an OS-level advisory lock held on a sibling ".lock" file,
+// used to keep concurrent `instill` invocations from corrupting config.yml
+// or hosts.yml.
+type fileLock struct {
+	f *os.File
+}
+
+func lockConfigFile(target string) (*fileLock, error) {
+	f, err := os.OpenFile(target+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	overlapped := new(windows.Overlapped)
+	if err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, overlapped); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &fileLock{f: f}, nil
+}
+
+func (l *fileLock) Unlock() error {
+	defer l.f.Close()
+	overlapped := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(l.f.Fd()), 0, 1, 0, overlapped)
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/instill-ai/cli/internal/config/configschema"
+)
+
+// Provenance names where a resolved config value came from, in increasing
+// order of precedence.
+type Provenance string
+
+const (
+	ProvenanceDefault Provenance = "default"
+	ProvenanceConfig  Provenance = "config"
+	ProvenanceEnv     Provenance = "env"
+	ProvenanceFlag    Provenance = "flag"
+)
+
+// ResolvedValue is one entry in the effective, merged view of the config
+// surface: the value instill will actually use for key, and which layer of
+// the overlay chain produced it.
+type ResolvedValue struct {
+	Key        string
+	Value      string
+	Provenance Provenance
+	Source     string
+}
+
+// Resolve computes the effective value of a schema key by walking the
+// overlay chain schema-default -> config.yml/hosts.yml -> INSTILL_* env var
+// -> command-line flag, in that order of increasing precedence. flagValue
+// is nil when the corresponding flag wasn't set on the command line.
+func Resolve(c Config, hostname, key string, flagValue *string) ResolvedValue {
+	rv := ResolvedValue{Key: key}
+
+	if field, ok := configschema.Lookup(key); ok && field.Default != "" {
+		rv.Value = field.Default
+		rv.Provenance = ProvenanceDefault
+		rv.Source = "schema default"
+	}
+
+	// Config.Get already applies the env overlay itself (see config_type.go),
+	// so when an env var is set this yields the env value labeled as
+	// "config" - harmless, since the explicit env check right below always
+	// runs afterward and corrects the provenance to ProvenanceEnv.
+	v, err := c.Get(hostname, key)
+	if err != nil && hostname != "" {
+		// No per-host value: fall back to the top-level config.yml key.
+		v, err = c.Get("", key)
+	}
+	if err == nil {
+		rv.Value = v
+		rv.Provenance = ProvenanceConfig
+		rv.Source = ConfigFile()
+	}
+
+	if field, ok := configschema.Lookup(key); ok && field.EnvVar != "" {
+		if v, ok := os.LookupEnv(field.EnvVar); ok {
+			rv.Value = v
+			rv.Provenance = ProvenanceEnv
+			rv.Source = field.EnvVar
+		}
+	} else if v, ok := os.LookupEnv(envVarFor(key)); ok {
+		rv.Value = v
+		rv.Provenance = ProvenanceEnv
+		rv.Source = envVarFor(key)
+	}
+
+	if flagValue != nil {
+		rv.Value = *flagValue
+		rv.Provenance = ProvenanceFlag
+		rv.Source = fmt.Sprintf("--%s", strings.ReplaceAll(key, "_", "-"))
+	}
+
+	return rv
+}
+
+// envVarFor derives the generic INSTILL_<KEY> override for a key that has
+// no explicit EnvVar declared in the schema.
+func envVarFor(key string) string {
+	return "INSTILL_" + strings.ToUpper(key)
+}
+
+// ResolveAll returns the resolved value of every known schema field.
+func ResolveAll(c Config, hostname string) []ResolvedValue {
+	out := make([]ResolvedValue, 0, len(configschema.Fields))
+	for _, f := range configschema.Fields {
+		out = append(out, Resolve(c, hostname, f.Key, nil))
+	}
+	return out
+}
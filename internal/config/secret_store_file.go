@@ -0,0 +1,206 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// fileSecretStore is the fallback SecretStore used when no OS keyring is
+// reachable (e.g., a headless Linux box with no D-Bus session for
+// libsecret). Secrets are encrypted at rest with an AES-GCM key derived via
+// scrypt from a randomly generated, 0600-permissioned master secret kept
+// alongside the ciphertext. This is still weaker than a real keyring - both
+// files live on the same disk, so anyone who can read one as the owning
+// user can read the other - but it resists the more common threats this
+// fallback is meant for: another local user, or a copy of secrets.enc
+// alone (e.g. grabbed off a backup) without the key file.
+type fileSecretStore struct {
+	path string
+}
+
+func newFileSecretStore() *fileSecretStore {
+	return &fileSecretStore{path: filepath.Join(ConfigDir(), "secrets.enc")}
+}
+
+type secretFileFormat struct {
+	Salt   string            `json:"salt"`
+	Nonce  map[string]string `json:"nonce"`
+	Values map[string]string `json:"values"`
+}
+
+func mapKey(service, key string) string {
+	return service + "/" + key
+}
+
+func (s *fileSecretStore) load() (*secretFileFormat, error) {
+	data, err := ReadConfigFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &secretFileFormat{Nonce: map[string]string{}, Values: map[string]string{}}, nil
+		}
+		return nil, err
+	}
+
+	var sf secretFileFormat
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, err
+	}
+	if sf.Nonce == nil {
+		sf.Nonce = map[string]string{}
+	}
+	if sf.Values == nil {
+		sf.Values = map[string]string{}
+	}
+	return &sf, nil
+}
+
+func (s *fileSecretStore) save(sf *secretFileFormat) error {
+	data, err := json.Marshal(sf)
+	if err != nil {
+		return err
+	}
+	return WriteConfigFile(s.path, data)
+}
+
+func (s *fileSecretStore) gcm(sf *secretFileFormat) (cipher.AEAD, error) {
+	if sf.Salt == "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		sf.Salt = base64.StdEncoding.EncodeToString(salt)
+	}
+	salt, err := base64.StdEncoding.DecodeString(sf.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := masterSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *fileSecretStore) Get(service, key string) (string, error) {
+	sf, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	mk := mapKey(service, key)
+	ciphertextB64, ok := sf.Values[mk]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found", mk)
+	}
+	nonceB64, ok := sf.Nonce[mk]
+	if !ok {
+		return "", fmt.Errorf("corrupt secret store: missing nonce for %q", mk)
+	}
+
+	gcm, err := s.gcm(sf)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(nonceB64)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(ciphertextB64)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func (s *fileSecretStore) Set(service, key, value string) error {
+	sf, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	gcm, err := s.gcm(sf)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, []byte(value), nil)
+
+	mk := mapKey(service, key)
+	sf.Values[mk] = base64.StdEncoding.EncodeToString(ciphertext)
+	sf.Nonce[mk] = base64.StdEncoding.EncodeToString(nonce)
+
+	return s.save(sf)
+}
+
+func (s *fileSecretStore) Delete(service, key string) error {
+	sf, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	mk := mapKey(service, key)
+	if _, ok := sf.Values[mk]; !ok {
+		return fmt.Errorf("secret %q not found", mk)
+	}
+	delete(sf.Values, mk)
+	delete(sf.Nonce, mk)
+	return s.save(sf)
+}
+
+func masterSecretPath() string {
+	return filepath.Join(ConfigDir(), "secrets.key")
+}
+
+// masterSecret returns the random, high-entropy passphrase the fallback
+// store's encryption key is derived from, generating and persisting one
+// (0600, via WriteConfigFile) the first time it's needed. Unlike a
+// hostname or other machine metadata, this isn't derivable by another
+// local user or by anyone who's only gotten a copy of secrets.enc.
+func masterSecret() ([]byte, error) {
+	if data, err := ReadConfigFile(masterSecretPath()); err == nil {
+		if decoded, decErr := base64.StdEncoding.DecodeString(string(data)); decErr == nil && len(decoded) == 32 {
+			return decoded, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := WriteConfigFile(masterSecretPath(), []byte(base64.StdEncoding.EncodeToString(secret))); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import "github.com/zalando/go-keyring"
+
+// keyringStore delegates to the native OS credential store. go-keyring
+// itself picks the backend per platform: macOS Keychain, Windows
+// Credential Manager, or libsecret/GNOME Keyring on Linux.
+type keyringStore struct{}
+
+func newKeyringStore() SecretStore {
+	return keyringStore{}
+}
+
+func (keyringStore) Get(service, key string) (string, error) {
+	return keyring.Get(service, key)
+}
+
+func (keyringStore) Set(service, key, value string) error {
+	return keyring.Set(service, key, value)
+}
+
+func (keyringStore) Delete(service, key string) error {
+	return keyring.Delete(service, key)
+}
@@ -0,0 +1,38 @@
+package config
+
+import "testing"
+
+// TestSet_PlaintextFlagControlsSecretStorage closes the loop between the
+// --plaintext flag (wired to UsePlaintextSecrets in internal/cmd/context and
+// internal/cmd/config) and the behavior it's meant to control: that Set
+// actually skips the SecretStore redirect when it's set.
+func TestSet_PlaintextFlagControlsSecretStorage(t *testing.T) {
+	t.Setenv(InstillConfigDir, t.TempDir())
+	t.Cleanup(func() { UsePlaintextSecrets = false })
+
+	t.Run("default redirects through the secret store", func(t *testing.T) {
+		UsePlaintextSecrets = false
+		c := NewBlankConfig().(*cfg)
+		if err := c.Set("api.instill.tech", "oauth_token", "abc123"); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		host, _ := c.hostNode("api.instill.tech", false)
+		v, ok := findEntry(host, "oauth_token")
+		if !ok || !isSecretRef(v.Value) {
+			t.Fatalf("expected a keyring reference in hosts.yml, got %q", v.Value)
+		}
+	})
+
+	t.Run("--plaintext stores the value verbatim", func(t *testing.T) {
+		UsePlaintextSecrets = true
+		c := NewBlankConfig().(*cfg)
+		if err := c.Set("api.instill.tech", "oauth_token", "abc123"); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		host, _ := c.hostNode("api.instill.tech", false)
+		v, ok := findEntry(host, "oauth_token")
+		if !ok || v.Value != "abc123" {
+			t.Fatalf("expected the plaintext value stored verbatim in hosts.yml, got %q", v.Value)
+		}
+	})
+}